@@ -0,0 +1,53 @@
+// Command postrenderer implements helm's `--post-renderer` protocol: it
+// reads a rendered manifest on stdin, applies the patches referenced by
+// its first argument (a path to a JSON file holding a
+// []orchestv1alpha1.PostRenderer), and writes the patched manifest to
+// stdout. HelmDeployer.Enable passes the path to this binary, alongside the
+// patches file, via `--post-renderer`/`--post-renderer-args`.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm/postrender"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("postrenderer: expected exactly one argument, the path to a patches file")
+	}
+
+	patchesFile, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("postrenderer: failed to read patches file: %w", err)
+	}
+
+	var patches []orchestv1alpha1.PostRenderer
+	if err := json.Unmarshal(patchesFile, &patches); err != nil {
+		return fmt.Errorf("postrenderer: failed to parse patches file: %w", err)
+	}
+
+	manifest, err := io.ReadAll(stdin)
+	if err != nil {
+		return fmt.Errorf("postrenderer: failed to read manifest from stdin: %w", err)
+	}
+
+	patched, err := postrender.Apply(string(manifest), patches)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, patched)
+	return err
+}
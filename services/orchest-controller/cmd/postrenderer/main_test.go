@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx-ingress
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx-ingress
+`
+
+func TestRun_AppliesPatchesFile(t *testing.T) {
+	patchesFile, err := os.CreateTemp("", "patches-*.json")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(patchesFile.Name())
+
+	patches := `[{"target":{"kind":"Deployment","name":"nginx-ingress"},"jsonPatch":"[{\"op\":\"add\",\"path\":\"/spec/template/spec/nodeSelector\",\"value\":{\"disktype\":\"ssd\"}}]"}]`
+	if _, err := patchesFile.WriteString(patches); err != nil {
+		t.Fatalf("failed to write patches file: %v", err)
+	}
+	patchesFile.Close()
+
+	var out bytes.Buffer
+	if err := run([]string{patchesFile.Name()}, strings.NewReader(testManifest), &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(out.String(), "disktype: ssd") {
+		t.Fatalf("expected patched manifest to contain the nodeSelector, got:\n%s", out.String())
+	}
+}
+
+func TestRun_RequiresExactlyOneArgument(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(nil, strings.NewReader(testManifest), &out); err == nil {
+		t.Fatal("expected an error when no patches file is given")
+	}
+}
@@ -0,0 +1,28 @@
+package addons
+
+import (
+	"context"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
+)
+
+// PreInstallHookFn is run before an Addon is installed or upgraded, e.g. to
+// materialize secrets or config the application depends on.
+type PreInstallHookFn func(app *orchestv1alpha1.ApplicationSpec) error
+
+// Addon represents a single, independently deployable component of an
+// OrchestCluster, e.g. an nginx-ingress or argo-workflows Helm chart.
+type Addon interface {
+	// Enable installs or upgrades the addon if its desired configuration
+	// has changed.
+	Enable(ctx context.Context, preInstallHooks []PreInstallHookFn,
+		namespace string, app *orchestv1alpha1.ApplicationSpec) error
+
+	// Uninstall removes the addon from namespace.
+	Uninstall(ctx context.Context, namespace string) error
+
+	// Status reports the readiness of every resource the addon deployed
+	// into namespace.
+	Status(ctx context.Context, namespace string) ([]statuscheck.ResourceStatus, error)
+}
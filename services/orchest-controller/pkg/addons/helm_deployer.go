@@ -2,31 +2,49 @@ package addons
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"gopkg.in/yaml.v2"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/orchest/orchest/services/orchest-controller/pkg/helm"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm/valueresolver"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
 )
 
 type HelmDeployer struct {
-	name       string
-	client     kubernetes.Interface
-	deployDir  string
-	valuesPath string
+	name             string
+	client           kubernetes.Interface
+	helmClient       helm.HelmClient
+	resolver         *valueresolver.Registry
+	postRendererPath string
+	deployDir        string
+	valuesPath       string
 }
 
-func NewHelmDeployer(client kubernetes.Interface,
+func NewHelmDeployer(client kubernetes.Interface, helmClient helm.HelmClient, resolver *valueresolver.Registry,
+	postRendererPath string,
 	name, deployDir string,
 	valuesPath string) Addon {
+	if resolver == nil {
+		// A nil registry has no schemes registered, so it still resolves
+		// any literal (non "ref+...") parameter unchanged -- callers that
+		// don't need ref+ indirections shouldn't have to construct one.
+		resolver = valueresolver.NewRegistry()
+	}
 	return &HelmDeployer{
-		name:       name,
-		client:     client,
-		deployDir:  deployDir,
-		valuesPath: valuesPath,
+		name:             name,
+		client:           client,
+		helmClient:       helmClient,
+		resolver:         resolver,
+		postRendererPath: postRendererPath,
+		deployDir:        deployDir,
+		valuesPath:       valuesPath,
 	}
 }
 
@@ -52,23 +70,59 @@ func (d *HelmDeployer) Enable(ctx context.Context, preInstallHooks []PreInstallH
 		deployArgs.WithValuesFile(d.valuesPath)
 	}
 
-	if app != nil && app.Config.Helm != nil && app.Config.Helm.Parameters != nil {
+	if app != nil && app.Config.Helm != nil {
 		for _, parameter := range app.Config.Helm.Parameters {
-			deployArgs.WithSetValue(parameter.Name, parameter.Value)
+			value, err := d.resolver.Resolve(ctx, parameter.Value)
+			if err != nil {
+				return err
+			}
+			deployArgs.WithSetValue(parameter.Name, value)
+		}
+
+		if len(app.Config.Helm.ValuesFrom) > 0 {
+			valuesFile, cleanup, err := d.materializeResolvedValues(ctx, app.Config.Helm.ValuesFrom)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			deployArgs.WithValuesFile(valuesFile)
+		}
+
+		if len(app.Config.Helm.PostRenderers) > 0 {
+			patchesFile, cleanup, err := d.materializePostRenderers(app.Config.Helm.PostRenderers)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			deployArgs.WithPostRenderer(d.postRendererPath, patchesFile)
 		}
 	}
 
 	deployArgs.WithRepository(d.deployDir)
 
+	// Capture the last-good revision so a readiness failure after a
+	// successful upgrade can be rolled back to it. A missing release
+	// (fresh install) leaves previousRevision at 0, meaning "nothing to
+	// roll back to".
+	previousRevision := d.getRevision(ctx, releaseName, namespace)
+
 	// First, we need to check if there is already a release, and if yes get the manifests stored
 	// in helm-related secret, and if the manifest can not be found, we will deploy the release
-	oldConfig, err := helm.GetReleaseConfig(ctx, releaseName, namespace)
+	oldConfig, err := d.helmClient.GetReleaseConfig(ctx, releaseName, namespace)
 	if err == nil {
 		// oldConfig exists, check if an update is required by getting the new config and comparing
 		// it to the old config, if the manifest is the same, no update is required.
 
-		// helm template generates the manifest without connecting to the k8s API server
-		newConfig, err := helm.RunCommand(ctx, deployArgs.WithTemplate().Build())
+		// helm template generates the manifest without connecting to the k8s
+		// API server. --history-max is an upgrade-only flag that helm
+		// template doesn't register, so it must not be added to deployArgs
+		// until after this diff check. It also runs against its own clone of
+		// deployArgs: WithTemplate/WithUpgradeInstall both prepend their verb
+		// in place, so sharing the builder with the upgrade call further
+		// down would stack "upgrade --install" onto "template".
+		newConfig, err := d.helmClient.RunCommand(ctx, deployArgs.Clone().WithTemplate().Build())
 		if err != nil {
 			// Failed to get new config, probably it is best to not update
 			return err
@@ -80,7 +134,7 @@ func (d *HelmDeployer) Enable(ctx context.Context, preInstallHooks []PreInstallH
 			return nil
 		}
 
-		err = helm.RemoveHelmHistoryIfNeeded(ctx, d.client, releaseName, namespace)
+		err = d.helmClient.RemoveHelmHistoryIfNeeded(ctx, d.client, releaseName, namespace)
 		if err != nil {
 			return err
 		}
@@ -94,12 +148,164 @@ func (d *HelmDeployer) Enable(ctx context.Context, preInstallHooks []PreInstallH
 		}
 	}
 
-	_, err = helm.RunCommand(ctx, deployArgs.WithUpgradeInstall().Build())
-	return err
+	historyMax := int32(orchestv1alpha1.DefaultHistoryMax)
+	if app != nil && app.Config.Helm != nil && app.Config.Helm.HistoryMax != nil {
+		historyMax = *app.Config.Helm.HistoryMax
+	}
+	deployArgs.WithHistoryMax(historyMax)
+
+	if _, err := d.helmClient.RunCommand(ctx, deployArgs.WithUpgradeInstall().Build()); err != nil {
+		return err
+	}
+
+	if app != nil && app.RollbackOnFailure && previousRevision > 0 {
+		statuses, err := d.Status(ctx, namespace)
+		if err != nil {
+			return err
+		}
+
+		if !statuscheck.AllReady(statuses) {
+			if rollbackErr := d.Rollback(ctx, namespace, previousRevision); rollbackErr != nil {
+				return fmt.Errorf("addon became unready after upgrade and rollback to revision %d failed: %w", previousRevision, rollbackErr)
+			}
+			return fmt.Errorf("addon became unready after upgrade, rolled back to revision %d", previousRevision)
+		}
+	}
+
+	return nil
+}
+
+// getRevision returns the currently deployed revision of releaseName in
+// namespace, or 0 if it cannot be determined (e.g. there is no release
+// yet).
+func (d *HelmDeployer) getRevision(ctx context.Context, releaseName, namespace string) int {
+	raw, err := d.helmClient.GetReleaseMetadata(ctx, releaseName, namespace)
+	if err != nil {
+		return 0
+	}
+
+	var metadata struct {
+		Revision int `json:"revision"`
+	}
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		return 0
+	}
+
+	return metadata.Revision
+}
+
+// Rollback rolls the release back to revision.
+func (d *HelmDeployer) Rollback(ctx context.Context, namespace string, revision int) error {
+	return d.helmClient.Rollback(ctx, d.getReleaseName(namespace), namespace, revision)
+}
+
+// materializeResolvedValues resolves every entry in valuesFrom and writes
+// the result to a 0600 temp values file, so that secrets pulled from Vault,
+// AWS SSM, a Kubernetes Secret or a SOPS-encrypted file never touch disk in
+// plaintext outside of it. The returned cleanup func removes the file and
+// must be called once the helm invocation that consumes it has finished.
+func (d *HelmDeployer) materializeResolvedValues(ctx context.Context,
+	valuesFrom []orchestv1alpha1.ValuesFromSource) (string, func(), error) {
+
+	values := map[string]interface{}{}
+	for _, entry := range valuesFrom {
+		resolved, err := d.resolver.Resolve(ctx, entry.Ref)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to resolve valuesFrom entry %q: %w", entry.Key, err)
+		}
+
+		setNestedKey(values, entry.Key, resolved)
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := os.CreateTemp("", "orchest-resolved-values-*.yaml")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(file.Name()) }
+
+	if err := file.Chmod(0600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// materializePostRenderers writes postRenderers to a temp JSON file that
+// cmd/postrenderer reads at helm-invocation time via
+// `--post-renderer-args`. The returned cleanup func removes the file and
+// must be called once the helm invocation that consumes it has finished.
+func (d *HelmDeployer) materializePostRenderers(postRenderers []orchestv1alpha1.PostRenderer) (string, func(), error) {
+	data, err := json.Marshal(postRenderers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := os.CreateTemp("", "orchest-post-renderers-*.json")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(file.Name()) }
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// setNestedKey sets value at the "."-separated dottedKey inside values,
+// creating intermediate maps as needed.
+func setNestedKey(values map[string]interface{}, dottedKey, value string) {
+	segments := strings.Split(dottedKey, ".")
+
+	current := values
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[segment] = next
+		}
+		current = next
+	}
 
+	current[segments[len(segments)-1]] = value
 }
 
 // Uninstall the addon
 func (d *HelmDeployer) Uninstall(ctx context.Context, namespace string) error {
-	return helm.RemoveRelease(ctx, d.getReleaseName(namespace), namespace)
+	return d.helmClient.RemoveRelease(ctx, d.getReleaseName(namespace), namespace)
+}
+
+// Status reports the readiness of every resource in the release's last
+// deployed manifest.
+func (d *HelmDeployer) Status(ctx context.Context, namespace string) ([]statuscheck.ResourceStatus, error) {
+	releaseName := d.getReleaseName(namespace)
+
+	manifest, err := d.helmClient.GetReleaseConfig(ctx, releaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return statuscheck.Check(ctx, d.client, namespace, manifest)
 }
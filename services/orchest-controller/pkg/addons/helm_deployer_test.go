@@ -0,0 +1,180 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"k8s.io/client-go/kubernetes/fake"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm/mocks"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm/valueresolver"
+)
+
+const (
+	testNamespace        = "orchest"
+	testDeployDir        = "/deploy/nginx-ingress"
+	testRenderedManifest = "apiVersion: v1\nkind: ConfigMap\n"
+)
+
+func newTestHelmDeployer(t *testing.T) (*HelmDeployer, *mocks.MockHelmClient) {
+	ctrl := gomock.NewController(t)
+	helmClient := mocks.NewMockHelmClient(ctrl)
+	deployer := NewHelmDeployer(fake.NewSimpleClientset(), helmClient, valueresolver.NewRegistry(), "",
+		"nginx-ingress", testDeployDir, "").(*HelmDeployer)
+	return deployer, helmClient
+}
+
+func TestHelmDeployer_Enable_NoDiffNoUpdate(t *testing.T) {
+	deployer, helmClient := newTestHelmDeployer(t)
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return(testRenderedManifest, nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return(testRenderedManifest, nil)
+
+	err := deployer.Enable(context.Background(), nil, testNamespace, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestHelmDeployer_Enable_DiffTriggersUpgrade(t *testing.T) {
+	deployer, helmClient := newTestHelmDeployer(t)
+
+	var templateArgs, upgradeArgs []string
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return(testRenderedManifest, nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, args []string) (string, error) {
+			templateArgs = args
+			return "apiVersion: v1\nkind: Secret\n", nil
+		})
+	helmClient.EXPECT().RemoveHelmHistoryIfNeeded(gomock.Any(), gomock.Any(), gomock.Any(), testNamespace).Return(nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, args []string) (string, error) {
+			upgradeArgs = args
+			return "", nil
+		})
+
+	err := deployer.Enable(context.Background(), nil, testNamespace, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The diff check must run `helm template`, not the malformed
+	// "upgrade --install template ..." that results from both verbs being
+	// prepended onto the same builder.
+	if len(templateArgs) == 0 || templateArgs[0] != "template" {
+		t.Fatalf("expected the diff check to run `helm template ...`, got %v", templateArgs)
+	}
+	for _, arg := range templateArgs {
+		if arg == "--history-max" {
+			t.Fatalf("helm template does not register --history-max, got %v", templateArgs)
+		}
+	}
+
+	if len(upgradeArgs) < 2 || upgradeArgs[0] != "upgrade" || upgradeArgs[1] != "--install" {
+		t.Fatalf("expected the upgrade to run `helm upgrade --install ...`, got %v", upgradeArgs)
+	}
+	if !containsArg(upgradeArgs, "--history-max") {
+		t.Fatalf("expected the upgrade to set --history-max, got %v", upgradeArgs)
+	}
+}
+
+func containsArg(args []string, want string) bool {
+	for _, arg := range args {
+		if arg == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHelmDeployer_Enable_NilResolverAllowsLiteralParameters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	helmClient := mocks.NewMockHelmClient(ctrl)
+	deployer := NewHelmDeployer(fake.NewSimpleClientset(), helmClient, nil, "",
+		"nginx-ingress", testDeployDir, "").(*HelmDeployer)
+
+	app := &orchestv1alpha1.ApplicationSpec{
+		Config: orchestv1alpha1.ApplicationConfig{
+			Helm: &orchestv1alpha1.ApplicationConfigHelm{
+				Parameters: []orchestv1alpha1.HelmParameter{{Name: "replicaCount", Value: "3"}},
+			},
+		},
+	}
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", nil)
+
+	if err := deployer.Enable(context.Background(), nil, testNamespace, app); err != nil {
+		t.Fatalf("expected no error for a literal parameter with a nil resolver, got %v", err)
+	}
+}
+
+func TestHelmDeployer_Enable_PreInstallHookFails(t *testing.T) {
+	deployer, helmClient := newTestHelmDeployer(t)
+
+	hookErr := errors.New("failed to resolve secret")
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return(testRenderedManifest, nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("apiVersion: v1\nkind: Secret\n", nil)
+	helmClient.EXPECT().RemoveHelmHistoryIfNeeded(gomock.Any(), gomock.Any(), gomock.Any(), testNamespace).Return(nil)
+
+	hook := func(app *orchestv1alpha1.ApplicationSpec) error {
+		return hookErr
+	}
+
+	err := deployer.Enable(context.Background(), []PreInstallHookFn{hook}, testNamespace, nil)
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+}
+
+func TestHelmDeployer_Enable_InstallFromScratch(t *testing.T) {
+	deployer, helmClient := newTestHelmDeployer(t)
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return("", errors.New("release: not found"))
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", nil)
+
+	err := deployer.Enable(context.Background(), nil, testNamespace, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestHelmDeployer_Enable_RollsBackOnFailedReadiness(t *testing.T) {
+	deployer, helmClient := newTestHelmDeployer(t)
+
+	app := &orchestv1alpha1.ApplicationSpec{RollbackOnFailure: true}
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), gomock.Any(), testNamespace).Return(`{"revision":3}`, nil)
+	// oldConfig vs newConfig differ, so Enable proceeds past the diff check.
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).Return(testRenderedManifest, nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("apiVersion: v1\nkind: Secret\n", nil)
+	helmClient.EXPECT().RemoveHelmHistoryIfNeeded(gomock.Any(), gomock.Any(), gomock.Any(), testNamespace).Return(nil)
+	helmClient.EXPECT().RunCommand(gomock.Any(), gomock.Any()).Return("", nil)
+	// The post-upgrade Status() call re-fetches the release manifest.
+	helmClient.EXPECT().GetReleaseConfig(gomock.Any(), gomock.Any(), testNamespace).
+		Return("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: missing\n", nil)
+	helmClient.EXPECT().Rollback(gomock.Any(), gomock.Any(), testNamespace, 3).Return(nil)
+
+	err := deployer.Enable(context.Background(), nil, testNamespace, app)
+	if err == nil {
+		t.Fatal("expected an error reporting the rollback")
+	}
+	// The "missing" Deployment doesn't exist in the fake clientset, which
+	// Status() must treat as not-ready rather than erroring out -- otherwise
+	// this rollback path, and the gomock Rollback expectation above, would
+	// never be reached.
+	if !strings.Contains(err.Error(), "rolled back to revision 3") {
+		t.Fatalf("expected a rollback confirmation error, got %v", err)
+	}
+}
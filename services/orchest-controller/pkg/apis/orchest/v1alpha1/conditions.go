@@ -0,0 +1,27 @@
+package v1alpha1
+
+// Condition types reported on OrchestCluster.Status.Conditions.
+const (
+	// ConditionTypeAddonsReady reflects whether every addon's deployed
+	// resources (Deployments, StatefulSets, DaemonSets, Jobs, PVCs,
+	// LoadBalancer Services, ...) are ready.
+	ConditionTypeAddonsReady = "AddonsReady"
+)
+
+const (
+	ConditionStatusTrue  = "True"
+	ConditionStatusFalse = "False"
+)
+
+// SetCondition upserts condition into status.Conditions, replacing any
+// existing condition of the same Type.
+func (status *OrchestClusterStatus) SetCondition(condition OrchestClusterCondition) {
+	for i, existing := range status.Conditions {
+		if existing.Type == condition.Type {
+			status.Conditions[i] = condition
+			return
+		}
+	}
+
+	status.Conditions = append(status.Conditions, condition)
+}
@@ -0,0 +1,151 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrchestCluster is the Schema for the orchestclusters API.
+type OrchestCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrchestClusterSpec   `json:"spec,omitempty"`
+	Status OrchestClusterStatus `json:"status,omitempty"`
+}
+
+// OrchestClusterSpec defines the desired state of OrchestCluster.
+type OrchestClusterSpec struct {
+	// Applications are the third-party applications to deploy as part of
+	// the OrchestCluster, e.g. argo-workflows, docker-registry.
+	Applications []ApplicationSpec `json:"applications,omitempty"`
+
+	// RabbitMq holds the configuration of the RabbitMq message broker
+	// used internally by Orchest.
+	RabbitMq RabbitMqSpec `json:"rabbitMq,omitempty"`
+}
+
+// OrchestClusterStatus defines the observed state of OrchestCluster.
+type OrchestClusterStatus struct {
+	Conditions []OrchestClusterCondition `json:"conditions,omitempty"`
+}
+
+// OrchestClusterCondition describes the state of an OrchestCluster at a
+// certain point.
+type OrchestClusterCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RabbitMqSpec holds the configuration of the RabbitMq StatefulSet.
+type RabbitMqSpec struct {
+	Image string `json:"image,omitempty"`
+
+	// Replicas is the number of RabbitMq cluster nodes. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the resource requirements of the rabbitmq container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Storage is the size of the PVC provisioned per RabbitMq pod through
+	// volumeClaimTemplates.
+	Storage resource.Quantity `json:"storage,omitempty"`
+}
+
+// ApplicationSpec describes a single application to be deployed by the
+// orchest-controller.
+type ApplicationSpec struct {
+	// Name is the name of the application, e.g. "argo-workflows".
+	Name string `json:"name,omitempty"`
+
+	// Config holds the application specific configuration.
+	Config ApplicationConfig `json:"config,omitempty"`
+
+	// RollbackOnFailure, when true, makes the controller automatically
+	// roll this application back to its last-good revision if the
+	// statuscheck readiness check fails after a successful
+	// `helm upgrade --install`.
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
+
+// ApplicationConfig holds the per-application configuration.
+type ApplicationConfig struct {
+	Helm *ApplicationConfigHelm `json:"helm,omitempty"`
+}
+
+// ApplicationConfigHelm holds the Helm specific configuration of an
+// application.
+type ApplicationConfigHelm struct {
+	// Parameters are passed to helm as --set key=value.
+	Parameters []HelmParameter `json:"parameters,omitempty"`
+
+	// ValuesFrom is a list of additional values, resolved through
+	// pkg/helm/valueresolver, that are merged into an additional values
+	// file passed to helm. Entries are applied in order, so later
+	// entries take precedence over earlier ones.
+	ValuesFrom []ValuesFromSource `json:"valuesFrom,omitempty"`
+
+	// PostRenderers are applied, in order, to the manifest helm renders
+	// before it is installed/upgraded, e.g. to inject a nodeSelector or
+	// imagePullSecret into a third-party chart without forking it.
+	PostRenderers []PostRenderer `json:"postRenderers,omitempty"`
+
+	// HistoryMax caps the number of revisions helm keeps for this
+	// release, passed as `--history-max`. Defaults to 10.
+	HistoryMax *int32 `json:"historyMax,omitempty"`
+}
+
+// DefaultHistoryMax is the `--history-max` used when
+// ApplicationConfigHelm.HistoryMax is unset.
+const DefaultHistoryMax = 10
+
+// PostRenderer patches every resource matching Target. Exactly one of
+// JSONPatch or StrategicMergePatch must be set.
+type PostRenderer struct {
+	// Target selects which rendered resource(s) this entry applies to.
+	Target PostRendererTarget `json:"target"`
+
+	// JSONPatch is a raw RFC 6902 JSON Patch document, e.g.
+	// `[{"op":"add","path":"/spec/template/spec/nodeSelector","value":{"disktype":"ssd"}}]`.
+	JSONPatch string `json:"jsonPatch,omitempty"`
+
+	// StrategicMergePatch is a YAML or JSON document merged into the
+	// matched resource using Kubernetes strategic-merge semantics (lists
+	// such as containers, env and tolerations are merged by their patch
+	// merge key, not replaced wholesale). For a Kind client-go's scheme
+	// has no Go type for, such as a CRD, it falls back to a plain RFC
+	// 7386 JSON Merge Patch, which does replace lists wholesale.
+	StrategicMergePatch string `json:"strategicMergePatch,omitempty"`
+}
+
+// PostRendererTarget selects the rendered resource(s) a PostRenderer
+// applies to by group/version/kind and, optionally, name. An empty Name
+// matches every resource of that Kind.
+type PostRendererTarget struct {
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version,omitempty"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name,omitempty"`
+}
+
+// HelmParameter is a single --set style Helm parameter. Value may be a
+// resolver reference, e.g. "ref+vault://secret/orchest/db#/password", in
+// which case it is resolved through pkg/helm/valueresolver before being
+// passed to helm.
+type HelmParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ValuesFromSource is a single entry merged into the additional values file
+// built for a release. Key is the dotted path in the values file the
+// resolved value is written to, e.g. "postgresql.auth.password". Ref is a
+// resolver reference understood by pkg/helm/valueresolver, e.g.
+// "ref+awsssm://orchest/db-password" or "ref+sops://secrets/db.enc.yaml#/password".
+type ValuesFromSource struct {
+	Key string `json:"key"`
+	Ref string `json:"ref"`
+}
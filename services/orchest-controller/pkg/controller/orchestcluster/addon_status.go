@@ -0,0 +1,38 @@
+package orchestcluster
+
+import (
+	"fmt"
+	"strings"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
+)
+
+// addonsReadyCondition aggregates the per-resource statuses of every addon,
+// keyed by addon name, into a single AddonsReady condition. It only builds
+// the condition: it does not set it on OrchestCluster.Status and does not
+// re-queue the reconcile.
+func addonsReadyCondition(statusesByAddon map[string][]statuscheck.ResourceStatus) orchestv1alpha1.OrchestClusterCondition {
+	var notReady []string
+
+	for addon, statuses := range statusesByAddon {
+		if !statuscheck.AllReady(statuses) {
+			notReady = append(notReady, addon)
+		}
+	}
+
+	if len(notReady) == 0 {
+		return orchestv1alpha1.OrchestClusterCondition{
+			Type:   orchestv1alpha1.ConditionTypeAddonsReady,
+			Status: orchestv1alpha1.ConditionStatusTrue,
+			Reason: "AllAddonsReady",
+		}
+	}
+
+	return orchestv1alpha1.OrchestClusterCondition{
+		Type:    orchestv1alpha1.ConditionTypeAddonsReady,
+		Status:  orchestv1alpha1.ConditionStatusFalse,
+		Reason:  "AddonsNotReady",
+		Message: fmt.Sprintf("waiting for addon(s) to become ready: %s", strings.Join(notReady, ", ")),
+	}
+}
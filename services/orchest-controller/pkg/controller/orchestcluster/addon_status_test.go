@@ -0,0 +1,29 @@
+package orchestcluster
+
+import (
+	"testing"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
+)
+
+func TestAddonsReadyCondition_AllReady(t *testing.T) {
+	condition := addonsReadyCondition(map[string][]statuscheck.ResourceStatus{
+		"nginx-ingress": {{Kind: "Deployment", Name: "nginx-ingress", Ready: true}},
+	})
+
+	if condition.Status != orchestv1alpha1.ConditionStatusTrue {
+		t.Fatalf("expected condition to be True, got %+v", condition)
+	}
+}
+
+func TestAddonsReadyCondition_SomeNotReady(t *testing.T) {
+	condition := addonsReadyCondition(map[string][]statuscheck.ResourceStatus{
+		"nginx-ingress": {{Kind: "Deployment", Name: "nginx-ingress", Ready: true}},
+		"argo-workflows": {{Kind: "Deployment", Name: "argo-workflows", Ready: false}},
+	})
+
+	if condition.Status != orchestv1alpha1.ConditionStatusFalse {
+		t.Fatalf("expected condition to be False, got %+v", condition)
+	}
+}
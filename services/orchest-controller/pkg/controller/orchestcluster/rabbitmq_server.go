@@ -1,17 +1,57 @@
 package orchestcluster
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
 	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	rabbitmqConfigVolume     = "rabbitmq-config"
+	rabbitmqErlangCookieName = "RABBITMQ_ERLANG_COOKIE"
+	rabbitmqAMQPPort         = 5672
+	rabbitmqDiscoveryPort    = 4369
+	rabbitmqClusteringPort   = 25672
 )
 
-func getRabbitMqManifest(hash string, orchest *orchestv1alpha1.OrchestCluster) *appsv1.Deployment {
+// rabbitmqConf configures the rabbit_peer_discovery_k8s plugin so that
+// RabbitMq nodes discover their peers through the headless Service instead
+// of a static node list, which is what lets the StatefulSet survive a
+// broker pod restart or a scale up/down.
+const rabbitmqConf = `cluster_formation.peer_discovery_backend = rabbit_peer_discovery_k8s
+cluster_formation.k8s.host = kubernetes.default.svc.cluster.local
+cluster_formation.k8s.address_type = hostname
+cluster_formation.node_cleanup.interval = 30
+cluster_formation.node_cleanup.only_log_warning = true
+cluster_partition_handling = autoheal
+queue_master_locator = min-masters
+`
+
+const rabbitmqEnabledPlugins = `[rabbitmq_management,rabbitmq_peer_discovery_k8s].
+`
+
+// getRabbitMqManifest builds the StatefulSet running the RabbitMq cluster
+// used internally by Orchest. Pods discover each other through
+// getRabbitMqHeadlessService and getRabbitMqConfigMap's rabbit_peer_discovery_k8s
+// configuration, so the set can be scaled and survives individual pod
+// restarts, unlike the single-replica Deployment with a shared PVC subpath
+// it replaces.
+func getRabbitMqManifest(hash string, orchest *orchestv1alpha1.OrchestCluster) *appsv1.StatefulSet {
 
 	matchLabels := getMatchLables(rabbitmq, orchest)
 	metadata := getMetadata(rabbitmq, hash, orchest)
 
+	replicas := int32(1)
+	if orchest.Spec.RabbitMq.Replicas != nil {
+		replicas = *orchest.Spec.RabbitMq.Replicas
+	}
+
 	template := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels: matchLabels,
@@ -19,22 +59,37 @@ func getRabbitMqManifest(hash string, orchest *orchestv1alpha1.OrchestCluster) *
 		Spec: corev1.PodSpec{
 			Volumes: []corev1.Volume{
 				{
-					Name: userDirName,
+					Name: rabbitmqConfigVolume,
 					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: userDirName,
-							ReadOnly:  false,
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: getRabbitMqConfigMap(hash, orchest).Name,
+							},
 						},
 					},
 				},
 			},
 			Containers: []corev1.Container{
 				{
-					Name:  rabbitmq,
-					Image: orchest.Spec.RabbitMq.Image,
+					Name:      rabbitmq,
+					Image:     orchest.Spec.RabbitMq.Image,
+					Resources: orchest.Spec.RabbitMq.Resources,
 					Ports: []corev1.ContainerPort{
+						{Name: "amqp", ContainerPort: rabbitmqAMQPPort},
+						{Name: "discovery", ContainerPort: rabbitmqDiscoveryPort},
+						{Name: "clustering", ContainerPort: rabbitmqClusteringPort},
+					},
+					Env: []corev1.EnvVar{
 						{
-							ContainerPort: 5672,
+							Name: rabbitmqErlangCookieName,
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: getRabbitMqErlangCookieSecretName(orchest),
+									},
+									Key: rabbitmqErlangCookieName,
+								},
+							},
 						},
 					},
 					VolumeMounts: []corev1.VolumeMount{
@@ -43,27 +98,150 @@ func getRabbitMqManifest(hash string, orchest *orchestv1alpha1.OrchestCluster) *
 							MountPath: rabbitmountPath,
 							SubPath:   rabbitSubPath,
 						},
+						{
+							Name:      rabbitmqConfigVolume,
+							MountPath: "/etc/rabbitmq/rabbitmq.conf",
+							SubPath:   "rabbitmq.conf",
+						},
+						{
+							Name:      rabbitmqConfigVolume,
+							MountPath: "/etc/rabbitmq/enabled_plugins",
+							SubPath:   "enabled_plugins",
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{
+								Command: []string{"rabbitmq-diagnostics", "check_running"},
+							},
+						},
+						InitialDelaySeconds: 20,
+						PeriodSeconds:       15,
 					},
 				},
 			},
 		},
 	}
 
-	deployment := &appsv1.Deployment{
+	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metadata,
-		Spec: appsv1.DeploymentSpec{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: getRabbitMqHeadlessService(orchest).Name,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: matchLabels,
 			},
 			Template: template,
-			Strategy: appsv1.DeploymentStrategy{
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &Zero,
+			// Parallel lets every RabbitMq node start at once instead of
+			// waiting for the previous ordinal to become Ready, which
+			// rabbit_peer_discovery_k8s needs anyway to form a cluster.
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: userDirName,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: orchest.Spec.RabbitMq.Storage,
+							},
+						},
+					},
 				},
 			},
 		},
 	}
 
-	return deployment
+	return statefulSet
+
+}
+
+// getRabbitMqHeadlessService returns the headless Service
+// rabbit_peer_discovery_k8s uses to enumerate the other pods in the
+// StatefulSet.
+func getRabbitMqHeadlessService(orchest *orchestv1alpha1.OrchestCluster) *corev1.Service {
+
+	matchLabels := getMatchLables(rabbitmq, orchest)
+	metadata := getMetadata(rabbitmq, "", orchest)
+	delete(metadata.Labels, "app.kubernetes.io/version")
+
+	return &corev1.Service{
+		ObjectMeta: metadata,
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  matchLabels,
+			Ports: []corev1.ServicePort{
+				{Name: "amqp", Port: rabbitmqAMQPPort, TargetPort: intstr.FromString("amqp")},
+				{Name: "discovery", Port: rabbitmqDiscoveryPort, TargetPort: intstr.FromString("discovery")},
+				{Name: "clustering", Port: rabbitmqClusteringPort, TargetPort: intstr.FromString("clustering")},
+			},
+		},
+	}
+}
+
+// getRabbitMqErlangCookieSecretName returns the name of the Secret holding
+// the shared Erlang cookie, without generating or reading its contents.
+func getRabbitMqErlangCookieSecretName(orchest *orchestv1alpha1.OrchestCluster) string {
+	metadata := getMetadata(rabbitmq, "", orchest)
+	return fmt.Sprintf("%s-cookie", metadata.Name)
+}
 
+// getRabbitMqErlangCookieSecret returns the Secret holding the shared
+// Erlang cookie every RabbitMq node in the cluster must use to
+// authenticate to its peers. The cookie must stay stable across
+// reconciles: if existingCookie is non-empty (read from the Secret already
+// live on the cluster) it is reused as-is; a fresh cookie is only
+// generated the first time the Secret is created. Re-randomizing it on
+// every reconcile would desync nodes the moment the Secret is re-applied
+// or a broker pod restarts.
+func getRabbitMqErlangCookieSecret(orchest *orchestv1alpha1.OrchestCluster, existingCookie string) *corev1.Secret {
+
+	metadata := getMetadata(rabbitmq, "", orchest)
+	delete(metadata.Labels, "app.kubernetes.io/version")
+	metadata.Name = fmt.Sprintf("%s-cookie", metadata.Name)
+
+	cookie := existingCookie
+	if cookie == "" {
+		cookie = generateErlangCookie()
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metadata,
+		StringData: map[string]string{
+			rabbitmqErlangCookieName: cookie,
+		},
+	}
+}
+
+// generateErlangCookie returns a random, base64-encoded cookie suitable for
+// RABBITMQ_ERLANG_COOKIE.
+func generateErlangCookie() string {
+	buf := make([]byte, 32)
+	// crypto/rand.Read only errors if the system's entropy source is
+	// unavailable, which is unrecoverable here.
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// getRabbitMqConfigMap returns the ConfigMap mounted as rabbitmq.conf and
+// enabled_plugins, configuring the rabbit_peer_discovery_k8s plugin.
+func getRabbitMqConfigMap(hash string, orchest *orchestv1alpha1.OrchestCluster) *corev1.ConfigMap {
+
+	metadata := getMetadata(rabbitmq, hash, orchest)
+	metadata.Name = fmt.Sprintf("%s-config", metadata.Name)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metadata,
+		Data: map[string]string{
+			"rabbitmq.conf":   rabbitmqConf,
+			"enabled_plugins": rabbitmqEnabledPlugins,
+		},
+	}
 }
@@ -0,0 +1,87 @@
+package orchestcluster
+
+import (
+	"testing"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestOrchestCluster() *orchestv1alpha1.OrchestCluster {
+	return &orchestv1alpha1.OrchestCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "orchest", Namespace: "orchest"},
+		Spec: orchestv1alpha1.OrchestClusterSpec{
+			RabbitMq: orchestv1alpha1.RabbitMqSpec{
+				Image:   "rabbitmq:3.11-management",
+				Storage: resource.MustParse("10Gi"),
+			},
+		},
+	}
+}
+
+func TestGetRabbitMqManifest_DefaultsToOneReplica(t *testing.T) {
+	statefulSet := getRabbitMqManifest("abc123", newTestOrchestCluster())
+
+	if statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas != 1 {
+		t.Fatalf("expected 1 replica by default, got %+v", statefulSet.Spec.Replicas)
+	}
+}
+
+func TestGetRabbitMqManifest_HonoursReplicas(t *testing.T) {
+	orchest := newTestOrchestCluster()
+	replicas := int32(3)
+	orchest.Spec.RabbitMq.Replicas = &replicas
+
+	statefulSet := getRabbitMqManifest("abc123", orchest)
+
+	if *statefulSet.Spec.Replicas != 3 {
+		t.Fatalf("expected 3 replicas, got %d", *statefulSet.Spec.Replicas)
+	}
+}
+
+func TestGetRabbitMqManifest_ParallelPodManagement(t *testing.T) {
+	statefulSet := getRabbitMqManifest("abc123", newTestOrchestCluster())
+
+	if statefulSet.Spec.PodManagementPolicy != appsv1.ParallelPodManagement {
+		t.Fatalf("expected ParallelPodManagement, got %s", statefulSet.Spec.PodManagementPolicy)
+	}
+}
+
+func TestGetRabbitMqManifest_UsesVolumeClaimTemplate(t *testing.T) {
+	statefulSet := getRabbitMqManifest("abc123", newTestOrchestCluster())
+
+	if len(statefulSet.Spec.VolumeClaimTemplates) != 1 {
+		t.Fatalf("expected exactly 1 volume claim template, got %d", len(statefulSet.Spec.VolumeClaimTemplates))
+	}
+
+	claim := statefulSet.Spec.VolumeClaimTemplates[0]
+	got := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	want := resource.MustParse("10Gi")
+	if got.Cmp(want) != 0 {
+		t.Fatalf("expected storage request %s, got %s", want.String(), got.String())
+	}
+}
+
+func TestGetRabbitMqErlangCookieSecret_GeneratesWhenAbsent(t *testing.T) {
+	orchest := newTestOrchestCluster()
+
+	a := getRabbitMqErlangCookieSecret(orchest, "")
+	b := getRabbitMqErlangCookieSecret(orchest, "")
+
+	if a.StringData[rabbitmqErlangCookieName] == b.StringData[rabbitmqErlangCookieName] {
+		t.Fatal("expected a freshly generated cookie to be random per call")
+	}
+}
+
+func TestGetRabbitMqErlangCookieSecret_ReusesExisting(t *testing.T) {
+	orchest := newTestOrchestCluster()
+
+	secret := getRabbitMqErlangCookieSecret(orchest, "already-on-the-cluster")
+
+	if got := secret.StringData[rabbitmqErlangCookieName]; got != "already-on-the-cluster" {
+		t.Fatalf("expected the existing cookie to be reused, got %q", got)
+	}
+}
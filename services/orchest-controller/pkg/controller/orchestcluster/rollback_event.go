@@ -0,0 +1,17 @@
+package orchestcluster
+
+import (
+	"fmt"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// recordRollbackEvent records a Warning event on orchest when
+// HelmDeployer.Enable auto-rolled an application back to revision after its
+// readiness check failed.
+func recordRollbackEvent(recorder record.EventRecorder, orchest *orchestv1alpha1.OrchestCluster, appName string, revision int) {
+	recorder.Eventf(orchest, corev1.EventTypeWarning, "AddonRolledBack",
+		fmt.Sprintf("application %q became unready after upgrade, rolled back to revision %d", appName, revision))
+}
@@ -0,0 +1,43 @@
+package orchestcluster
+
+import (
+	"fmt"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	rabbitmq        = "rabbitmq"
+	userDirName     = "userdir-pvc"
+	rabbitmountPath = "/var/lib/rabbitmq/mnesia"
+	rabbitSubPath   = "rabbitmq-mnesia"
+)
+
+// Zero is an int32 zero value whose address can be taken where a manifest
+// needs a pointer to zero, e.g. RollingUpdateDeployment.MaxUnavailable.
+var Zero int32 = 0
+
+// getMatchLables returns the labels used to both select and label the pods
+// of component.
+func getMatchLables(component string, orchest *orchestv1alpha1.OrchestCluster) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      component,
+		"app.kubernetes.io/instance":  orchest.Name,
+		"app.kubernetes.io/component": component,
+	}
+}
+
+// getMetadata returns the ObjectMeta for a component-owned resource,
+// namespaced to orchest and labeled with hash so that a config change can
+// be detected by diffing labels across reconciles.
+func getMetadata(component, hash string, orchest *orchestv1alpha1.OrchestCluster) metav1.ObjectMeta {
+	labels := getMatchLables(component, orchest)
+	labels["app.kubernetes.io/version"] = hash
+
+	return metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%s-%s", orchest.Name, component),
+		Namespace: orchest.Namespace,
+		Labels:    labels,
+	}
+}
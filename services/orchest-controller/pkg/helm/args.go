@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// HelmArgBuilder incrementally builds the argument list for a `helm`
+// invocation.
+type HelmArgBuilder struct {
+	args []string
+}
+
+// NewHelmArgBuilder returns an empty HelmArgBuilder.
+func NewHelmArgBuilder() *HelmArgBuilder {
+	return &HelmArgBuilder{}
+}
+
+// Clone returns a copy of b with its own backing array, so that further
+// calls on the clone (e.g. WithTemplate for a dry-run diff) never mutate b
+// or any builder derived from it. WithTemplate and WithUpgradeInstall both
+// prepend their verb in place, so sharing a builder across two such calls
+// would stack both verbs onto the same argument list.
+func (b *HelmArgBuilder) Clone() *HelmArgBuilder {
+	args := make([]string, len(b.args))
+	copy(args, b.args)
+	return &HelmArgBuilder{args: args}
+}
+
+func (b *HelmArgBuilder) WithName(name string) *HelmArgBuilder {
+	b.args = append(b.args, name)
+	return b
+}
+
+func (b *HelmArgBuilder) WithNamespace(namespace string) *HelmArgBuilder {
+	b.args = append(b.args, "--namespace", namespace)
+	return b
+}
+
+func (b *HelmArgBuilder) WithCreateNamespace() *HelmArgBuilder {
+	b.args = append(b.args, "--create-namespace")
+	return b
+}
+
+func (b *HelmArgBuilder) WithAtomic() *HelmArgBuilder {
+	b.args = append(b.args, "--atomic")
+	return b
+}
+
+func (b *HelmArgBuilder) WithTimeout(timeout time.Duration) *HelmArgBuilder {
+	b.args = append(b.args, "--timeout", timeout.String())
+	return b
+}
+
+func (b *HelmArgBuilder) WithValuesFile(path string) *HelmArgBuilder {
+	b.args = append(b.args, "--values", path)
+	return b
+}
+
+func (b *HelmArgBuilder) WithSetValue(name, value string) *HelmArgBuilder {
+	b.args = append(b.args, "--set", fmt.Sprintf("%s=%s", name, value))
+	return b
+}
+
+func (b *HelmArgBuilder) WithRepository(repository string) *HelmArgBuilder {
+	b.args = append(b.args, repository)
+	return b
+}
+
+// WithHistoryMax sets `--history-max` to max.
+func (b *HelmArgBuilder) WithHistoryMax(max int32) *HelmArgBuilder {
+	b.args = append(b.args, "--history-max", strconv.Itoa(int(max)))
+	return b
+}
+
+// WithPostRenderer sets `--post-renderer` to path, passing args to it via
+// repeated `--post-renderer-args` flags.
+func (b *HelmArgBuilder) WithPostRenderer(path string, args ...string) *HelmArgBuilder {
+	b.args = append(b.args, "--post-renderer", path)
+	for _, arg := range args {
+		b.args = append(b.args, "--post-renderer-args", arg)
+	}
+	return b
+}
+
+// WithTemplate prepends the `template` verb so that the resulting manifest
+// can be rendered without talking to the k8s API server.
+func (b *HelmArgBuilder) WithTemplate() *HelmArgBuilder {
+	b.args = append([]string{"template"}, b.args...)
+	return b
+}
+
+// WithUpgradeInstall prepends the `upgrade --install` verb.
+func (b *HelmArgBuilder) WithUpgradeInstall() *HelmArgBuilder {
+	b.args = append([]string{"upgrade", "--install"}, b.args...)
+	return b
+}
+
+// Build returns the final argument list to pass to the helm binary.
+func (b *HelmArgBuilder) Build() []string {
+	return b.args
+}
@@ -0,0 +1,77 @@
+package helm
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// HelmClient abstracts the helm operations used by the addon deployers so
+// that they can be exercised in unit tests without shelling out to a real
+// helm binary.
+//
+//go:generate mockgen -destination=mocks/client_mock.go -package=mocks github.com/orchest/orchest/services/orchest-controller/pkg/helm HelmClient
+type HelmClient interface {
+	// GetReleaseConfig returns the manifest of the last deployed revision
+	// of releaseName in namespace.
+	GetReleaseConfig(ctx context.Context, releaseName, namespace string) (string, error)
+
+	// GetReleaseMetadata returns the `helm get metadata -o json` output
+	// for releaseName in namespace: chart name/version, app version,
+	// revision and last-deployed timestamp.
+	GetReleaseMetadata(ctx context.Context, releaseName, namespace string) (string, error)
+
+	// GetReleaseValues returns the `helm get values -o json` output for
+	// releaseName in namespace: the values it was last deployed with.
+	GetReleaseValues(ctx context.Context, releaseName, namespace string) (string, error)
+
+	// RunCommand runs `helm` with the given arguments and returns its
+	// stdout.
+	RunCommand(ctx context.Context, args []string) (string, error)
+
+	// RemoveHelmHistoryIfNeeded trims release secrets stuck in a
+	// pending/failed state so a following upgrade isn't blocked by them.
+	RemoveHelmHistoryIfNeeded(ctx context.Context, client kubernetes.Interface, releaseName, namespace string) error
+
+	// RemoveRelease uninstalls releaseName from namespace.
+	RemoveRelease(ctx context.Context, releaseName, namespace string) error
+
+	// Rollback rolls releaseName in namespace back to revision.
+	Rollback(ctx context.Context, releaseName, namespace string, revision int) error
+}
+
+// client is the default HelmClient, backed by the helm CLI.
+type client struct{}
+
+// NewClient returns the default HelmClient, backed by the helm CLI.
+func NewClient() HelmClient {
+	return &client{}
+}
+
+func (c *client) GetReleaseConfig(ctx context.Context, releaseName, namespace string) (string, error) {
+	return GetReleaseConfig(ctx, releaseName, namespace)
+}
+
+func (c *client) GetReleaseMetadata(ctx context.Context, releaseName, namespace string) (string, error) {
+	return GetReleaseMetadata(ctx, releaseName, namespace)
+}
+
+func (c *client) GetReleaseValues(ctx context.Context, releaseName, namespace string) (string, error) {
+	return GetReleaseValues(ctx, releaseName, namespace)
+}
+
+func (c *client) RunCommand(ctx context.Context, args []string) (string, error) {
+	return RunCommand(ctx, args)
+}
+
+func (c *client) RemoveHelmHistoryIfNeeded(ctx context.Context, k8sClient kubernetes.Interface, releaseName, namespace string) error {
+	return RemoveHelmHistoryIfNeeded(ctx, k8sClient, releaseName, namespace)
+}
+
+func (c *client) RemoveRelease(ctx context.Context, releaseName, namespace string) error {
+	return RemoveRelease(ctx, releaseName, namespace)
+}
+
+func (c *client) Rollback(ctx context.Context, releaseName, namespace string, revision int) error {
+	return Rollback(ctx, releaseName, namespace, revision)
+}
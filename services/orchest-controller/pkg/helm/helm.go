@@ -0,0 +1,88 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pendingStatuses are the release statuses helm leaves behind when an
+// upgrade was interrupted; a release stuck in one of these blocks any
+// future `helm upgrade --install` until it is cleaned up.
+var pendingStatuses = map[string]bool{
+	"pending-install":  true,
+	"pending-upgrade":  true,
+	"pending-rollback": true,
+	"uninstalling":     true,
+	"failed":           true,
+}
+
+// RunCommand runs the helm binary with the given arguments and returns its
+// stdout.
+func RunCommand(ctx context.Context, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, "helm", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("helm %v failed: %w: %s", args, err, stderr.String())
+	}
+	return string(out), nil
+}
+
+// GetReleaseConfig returns the manifest of the last deployed revision of
+// releaseName in namespace.
+func GetReleaseConfig(ctx context.Context, releaseName, namespace string) (string, error) {
+	return RunCommand(ctx, []string{"get", "manifest", releaseName, "--namespace", namespace})
+}
+
+// GetReleaseMetadata returns the `helm get metadata -o json` output for
+// releaseName in namespace.
+func GetReleaseMetadata(ctx context.Context, releaseName, namespace string) (string, error) {
+	return RunCommand(ctx, []string{"get", "metadata", releaseName, "--namespace", namespace, "-o", "json"})
+}
+
+// GetReleaseValues returns the `helm get values -o json` output for
+// releaseName in namespace.
+func GetReleaseValues(ctx context.Context, releaseName, namespace string) (string, error) {
+	return RunCommand(ctx, []string{"get", "values", releaseName, "--namespace", namespace, "-o", "json"})
+}
+
+// RemoveHelmHistoryIfNeeded deletes the helm release secrets for releaseName
+// that are stuck in a pending/failed state so that a following
+// `helm upgrade --install` is not blocked by them.
+func RemoveHelmHistoryIfNeeded(ctx context.Context, client kubernetes.Interface, releaseName, namespace string) error {
+	secrets, err := client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("owner=helm,name=%s", releaseName),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets.Items {
+		if pendingStatuses[secret.Labels["status"]] {
+			if err := client.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveRelease uninstalls releaseName from namespace.
+func RemoveRelease(ctx context.Context, releaseName, namespace string) error {
+	_, err := RunCommand(ctx, []string{"uninstall", releaseName, "--namespace", namespace})
+	return err
+}
+
+// Rollback rolls releaseName in namespace back to revision.
+func Rollback(ctx context.Context, releaseName, namespace string, revision int) error {
+	_, err := RunCommand(ctx, []string{"rollback", releaseName, strconv.Itoa(revision), "--namespace", namespace})
+	return err
+}
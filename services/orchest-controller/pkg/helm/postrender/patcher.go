@@ -0,0 +1,164 @@
+// Package postrender applies a chart-agnostic set of RFC 6902 JSON Patch or
+// strategic-merge patches to a rendered Helm manifest, so that operators can
+// inject site-specific tolerations, nodeSelectors, imagePullSecrets or
+// sidecars into a third-party chart (nginx-ingress, argo, registry, ...)
+// without forking it.
+package postrender
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8syaml "sigs.k8s.io/yaml"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+)
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+type resourceMeta struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+}
+
+// Apply applies every patch in patches, in order, to the resources in
+// manifest that match its Target, and returns the patched manifest.
+func Apply(manifest string, patches []orchestv1alpha1.PostRenderer) (string, error) {
+	var patchedDocs []string
+
+	for _, doc := range documentSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		patched, err := applyToDocument(doc, patches)
+		if err != nil {
+			return "", err
+		}
+
+		patchedDocs = append(patchedDocs, patched)
+	}
+
+	return strings.Join(patchedDocs, "---\n"), nil
+}
+
+func applyToDocument(doc string, patches []orchestv1alpha1.PostRenderer) (string, error) {
+	var meta resourceMeta
+	if err := yaml.Unmarshal([]byte(doc), &meta); err != nil {
+		return "", fmt.Errorf("postrender: failed to parse manifest document: %w", err)
+	}
+
+	group, version := splitAPIVersion(meta.APIVersion)
+	gvk := schema.GroupVersionKind{Group: group, Version: version, Kind: meta.Kind}
+
+	jsonDoc, err := k8syaml.YAMLToJSON([]byte(doc))
+	if err != nil {
+		return "", fmt.Errorf("postrender: failed to convert document to JSON: %w", err)
+	}
+
+	for _, patch := range patches {
+		if !matches(patch.Target, group, version, meta.Kind, meta.Metadata.Name) {
+			continue
+		}
+
+		switch {
+		case patch.JSONPatch != "":
+			jsonDoc, err = applyJSONPatch(jsonDoc, patch.JSONPatch)
+		case patch.StrategicMergePatch != "":
+			jsonDoc, err = applyMergePatch(jsonDoc, patch.StrategicMergePatch, gvk)
+		default:
+			err = fmt.Errorf("postrender: patch for %s must set jsonPatch or strategicMergePatch", patch.Target.Kind)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	patchedYAML, err := k8syaml.JSONToYAML(jsonDoc)
+	if err != nil {
+		return "", fmt.Errorf("postrender: failed to convert patched document back to YAML: %w", err)
+	}
+
+	return string(patchedYAML), nil
+}
+
+func applyJSONPatch(doc []byte, rawPatch string) ([]byte, error) {
+	patch, err := jsonpatch.DecodePatch([]byte(rawPatch))
+	if err != nil {
+		return nil, fmt.Errorf("postrender: invalid JSON patch: %w", err)
+	}
+
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		return nil, fmt.Errorf("postrender: failed to apply JSON patch: %w", err)
+	}
+
+	return patched, nil
+}
+
+// applyMergePatch applies rawPatch using true Kubernetes strategic-merge
+// semantics: lists with a known patchMergeKey (containers by name,
+// tolerations by key/effect/operator, ...) are merged element-by-element
+// instead of being replaced wholesale, so injecting a sidecar or an extra
+// toleration doesn't clobber the ones the chart already rendered.
+//
+// That merge metadata only exists for types client-go's scheme knows
+// about. For a Kind the scheme has no Go type for (typically a CRD), it
+// falls back to a plain RFC 7386 JSON Merge Patch, which does replace
+// lists wholesale.
+func applyMergePatch(doc []byte, rawPatch string, gvk schema.GroupVersionKind) ([]byte, error) {
+	patchJSON, err := k8syaml.YAMLToJSON([]byte(rawPatch))
+	if err != nil {
+		return nil, fmt.Errorf("postrender: invalid strategic merge patch: %w", err)
+	}
+
+	dataStruct, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		patched, err := jsonpatch.MergePatch(doc, patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("postrender: failed to apply strategic merge patch: %w", err)
+		}
+		return patched, nil
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(doc, patchJSON, dataStruct)
+	if err != nil {
+		return nil, fmt.Errorf("postrender: failed to apply strategic merge patch: %w", err)
+	}
+
+	return patched, nil
+}
+
+func splitAPIVersion(apiVersion string) (group, version string) {
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		// Core group resources have no "group/" prefix, e.g. "v1".
+		return "", apiVersion
+	}
+	return group, version
+}
+
+func matches(target orchestv1alpha1.PostRendererTarget, group, version, kind, name string) bool {
+	if target.Kind != kind {
+		return false
+	}
+	if target.Group != "" && target.Group != group {
+		return false
+	}
+	if target.Version != "" && target.Version != version {
+		return false
+	}
+	if target.Name != "" && target.Name != name {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package postrender
+
+import (
+	"strings"
+	"testing"
+
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+)
+
+const testDeploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx-ingress
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx-ingress
+`
+
+func TestApply_JSONPatch(t *testing.T) {
+	patches := []orchestv1alpha1.PostRenderer{
+		{
+			Target:    orchestv1alpha1.PostRendererTarget{Kind: "Deployment", Name: "nginx-ingress"},
+			JSONPatch: `[{"op":"add","path":"/spec/template/spec/nodeSelector","value":{"disktype":"ssd"}}]`,
+		},
+	}
+
+	patched, err := Apply(testDeploymentManifest, patches)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(patched, "disktype: ssd") {
+		t.Fatalf("expected patched manifest to contain the nodeSelector, got:\n%s", patched)
+	}
+}
+
+func TestApply_StrategicMergePatch(t *testing.T) {
+	patches := []orchestv1alpha1.PostRenderer{
+		{
+			Target: orchestv1alpha1.PostRendererTarget{Kind: "Deployment"},
+			StrategicMergePatch: `
+spec:
+  template:
+    spec:
+      imagePullSecrets:
+      - name: registry-credentials
+`,
+		},
+	}
+
+	patched, err := Apply(testDeploymentManifest, patches)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(patched, "registry-credentials") {
+		t.Fatalf("expected patched manifest to contain imagePullSecrets, got:\n%s", patched)
+	}
+}
+
+func TestApply_StrategicMergePatch_MergesContainersByName(t *testing.T) {
+	patches := []orchestv1alpha1.PostRenderer{
+		{
+			Target: orchestv1alpha1.PostRendererTarget{Kind: "Deployment"},
+			StrategicMergePatch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: envoy:latest
+`,
+		},
+	}
+
+	patched, err := Apply(testDeploymentManifest, patches)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(patched, "name: nginx-ingress") {
+		t.Fatalf("expected the chart's own container to survive the merge, got:\n%s", patched)
+	}
+	if !strings.Contains(patched, "name: sidecar") {
+		t.Fatalf("expected the sidecar container to be added, got:\n%s", patched)
+	}
+}
+
+func TestApply_NoMatchingTargetIsNoop(t *testing.T) {
+	patches := []orchestv1alpha1.PostRenderer{
+		{
+			Target:    orchestv1alpha1.PostRendererTarget{Kind: "Deployment", Name: "argo-workflows"},
+			JSONPatch: `[{"op":"add","path":"/spec/template/spec/nodeSelector","value":{"disktype":"ssd"}}]`,
+		},
+	}
+
+	patched, err := Apply(testDeploymentManifest, patches)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(patched, "disktype") {
+		t.Fatalf("expected manifest to be unchanged, got:\n%s", patched)
+	}
+}
@@ -0,0 +1,38 @@
+package valueresolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// SSMResolver resolves references of the form "ref+awsssm://<parameter-name>"
+// against AWS Systems Manager Parameter Store.
+type SSMResolver struct {
+	client *ssm.Client
+}
+
+// NewSSMResolver returns a Resolver backed by client.
+func NewSSMResolver(client *ssm.Client) *SSMResolver {
+	return &SSMResolver{client: client}
+}
+
+func (r *SSMResolver) Scheme() string {
+	return "awsssm"
+}
+
+func (r *SSMResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "awsssm://")
+
+	out, err := r.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(out.Parameter.Value), nil
+}
@@ -0,0 +1,69 @@
+// Package valueresolver resolves "ref+<scheme>://..." indirections found in
+// Helm parameter values to their plaintext value, mirroring the scheme used
+// by github.com/variantdev/vals. This lets an ApplicationSpec reference a
+// secret living in Vault, AWS SSM, a Kubernetes Secret or a SOPS-encrypted
+// file instead of embedding it in the CR.
+package valueresolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Resolver resolves references for a single scheme, e.g. "vault" or
+// "awsssm".
+type Resolver interface {
+	// Scheme is the "ref+<scheme>://" prefix this Resolver handles.
+	Scheme() string
+
+	// Resolve returns the plaintext value referenced by ref. ref has the
+	// "ref+" prefix already stripped but keeps its "<scheme>://" part, e.g.
+	// "vault://secret/orchest/db#/password".
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+const refPrefix = "ref+"
+
+// IsRef reports whether value is a resolver reference rather than a literal
+// value.
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, refPrefix)
+}
+
+// Registry dispatches a "ref+<scheme>://..." reference to the Resolver
+// registered for <scheme>.
+type Registry struct {
+	resolvers map[string]Resolver
+}
+
+// NewRegistry returns a Registry with resolvers registered under their own
+// Scheme().
+func NewRegistry(resolvers ...Resolver) *Registry {
+	r := &Registry{resolvers: make(map[string]Resolver, len(resolvers))}
+	for _, resolver := range resolvers {
+		r.resolvers[resolver.Scheme()] = resolver
+	}
+	return r
+}
+
+// Resolve resolves value if it is a resolver reference, otherwise it returns
+// value unchanged.
+func (r *Registry) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+
+	rest := strings.TrimPrefix(value, refPrefix)
+	scheme, _, found := strings.Cut(rest, "://")
+	if !found {
+		return "", fmt.Errorf("valueresolver: malformed reference %q", value)
+	}
+
+	resolver, ok := r.resolvers[scheme]
+	if !ok {
+		return "", fmt.Errorf("valueresolver: no resolver registered for scheme %q", scheme)
+	}
+
+	return resolver.Resolve(ctx, rest)
+}
@@ -0,0 +1,60 @@
+package valueresolver
+
+import (
+	"context"
+	"testing"
+)
+
+type stubResolver struct {
+	scheme string
+	value  string
+	err    error
+}
+
+func (s *stubResolver) Scheme() string { return s.scheme }
+
+func (s *stubResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	return s.value, s.err
+}
+
+func TestRegistry_Resolve_Literal(t *testing.T) {
+	registry := NewRegistry()
+
+	value, err := registry.Resolve(context.Background(), "plain-value")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value != "plain-value" {
+		t.Fatalf("expected literal value unchanged, got %q", value)
+	}
+}
+
+func TestRegistry_Resolve_DispatchesToScheme(t *testing.T) {
+	registry := NewRegistry(&stubResolver{scheme: "vault", value: "s3cr3t"})
+
+	value, err := registry.Resolve(context.Background(), "ref+vault://secret/orchest/db#/password")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value != "s3cr3t" {
+		t.Fatalf("expected resolved value, got %q", value)
+	}
+}
+
+func TestRegistry_Resolve_UnknownScheme(t *testing.T) {
+	registry := NewRegistry()
+
+	_, err := registry.Resolve(context.Background(), "ref+vault://secret/orchest/db#/password")
+	if err == nil {
+		t.Fatal("expected an error for an unregistered scheme")
+	}
+}
+
+func TestRegistry_Resolve_MalformedRef(t *testing.T) {
+	registry := NewRegistry()
+
+	_, err := registry.Resolve(context.Background(), "ref+vault-secret/orchest/db")
+	if err == nil {
+		t.Fatal("expected an error for a malformed reference")
+	}
+}
@@ -0,0 +1,50 @@
+package valueresolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SecretResolver resolves references of the form
+// "ref+secret://<namespace>/<name>#/<key>" against the in-cluster
+// Kubernetes API.
+type SecretResolver struct {
+	client kubernetes.Interface
+}
+
+// NewSecretResolver returns a Resolver backed by client.
+func NewSecretResolver(client kubernetes.Interface) *SecretResolver {
+	return &SecretResolver{client: client}
+}
+
+func (r *SecretResolver) Scheme() string {
+	return "secret"
+}
+
+func (r *SecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(strings.TrimPrefix(ref, "secret://"), "#/")
+	if !ok {
+		return "", fmt.Errorf("valueresolver: secret ref %q is missing a #/<key> fragment", ref)
+	}
+
+	namespace, name, ok := strings.Cut(path, "/")
+	if !ok {
+		return "", fmt.Errorf("valueresolver: secret ref %q is missing a namespace", ref)
+	}
+
+	secret, err := r.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("valueresolver: secret %s/%s has no key %q", namespace, name, key)
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,41 @@
+package valueresolver
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretResolver_Resolve(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "orchest"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	})
+
+	resolver := NewSecretResolver(client)
+
+	value, err := resolver.Resolve(context.Background(), "secret://orchest/db#/password")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value != "s3cr3t" {
+		t.Fatalf("expected %q, got %q", "s3cr3t", value)
+	}
+}
+
+func TestSecretResolver_Resolve_MissingKey(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "orchest"},
+		Data:       map[string][]byte{"other": []byte("value")},
+	})
+
+	resolver := NewSecretResolver(client)
+
+	_, err := resolver.Resolve(context.Background(), "secret://orchest/db#/password")
+	if err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+}
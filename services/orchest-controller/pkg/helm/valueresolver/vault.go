@@ -0,0 +1,47 @@
+package valueresolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultResolver resolves references of the form
+// "ref+vault://<mount>/<path>#/<key>" against a HashiCorp Vault KV secrets
+// engine.
+type VaultResolver struct {
+	client *vault.Client
+}
+
+// NewVaultResolver returns a Resolver backed by client.
+func NewVaultResolver(client *vault.Client) *VaultResolver {
+	return &VaultResolver{client: client}
+}
+
+func (r *VaultResolver) Scheme() string {
+	return "vault"
+}
+
+func (r *VaultResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(strings.TrimPrefix(ref, "vault://"), "#/")
+	if !ok {
+		return "", fmt.Errorf("valueresolver: vault ref %q is missing a #/<key> fragment", ref)
+	}
+
+	secret, err := r.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	if secret == nil {
+		return "", fmt.Errorf("valueresolver: no vault secret found at %q", path)
+	}
+
+	value, ok := secret.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("valueresolver: vault secret %q has no string key %q", path, key)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,37 @@
+package valueresolver
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// extractYAMLKey walks document along the "."-separated dottedKey and
+// returns the string found there.
+func extractYAMLKey(document []byte, dottedKey string) (string, error) {
+	var root map[string]interface{}
+	if err := yaml.Unmarshal(document, &root); err != nil {
+		return "", err
+	}
+
+	var current interface{} = root
+	for _, segment := range strings.Split(dottedKey, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("valueresolver: key %q not found", dottedKey)
+		}
+
+		current, ok = m[segment]
+		if !ok {
+			return "", fmt.Errorf("valueresolver: key %q not found", dottedKey)
+		}
+	}
+
+	value, ok := current.(string)
+	if !ok {
+		return "", fmt.Errorf("valueresolver: key %q is not a string", dottedKey)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,78 @@
+package addons
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Handler serves Service over HTTP:
+//
+//	GET /api/namespaces/{namespace}/addons            -> ListAddonReleases
+//	GET /api/namespaces/{namespace}/addons/{name}      -> GetAddonRelease
+type Handler struct {
+	service *Service
+}
+
+// NewHandler returns an http.Handler serving service.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace, name, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if name == "" {
+		releases, err := h.service.ListAddonReleases(r.Context(), namespace)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, releases)
+		return
+	}
+
+	release, err := h.service.GetAddonRelease(r.Context(), namespace, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, release)
+}
+
+// parsePath extracts namespace and, if present, name from
+// "/api/namespaces/{namespace}/addons[/{name}]".
+func parsePath(path string) (namespace, name string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch len(segments) {
+	case 4:
+		if segments[0] != "api" || segments[1] != "namespaces" || segments[3] != "addons" {
+			return "", "", false
+		}
+		return segments[2], "", true
+	case 5:
+		if segments[0] != "api" || segments[1] != "namespaces" || segments[3] != "addons" {
+			return "", "", false
+		}
+		return segments[2], segments[4], true
+	default:
+		return "", "", false
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,25 @@
+package addons
+
+import "testing"
+
+func TestParsePath(t *testing.T) {
+	cases := []struct {
+		path          string
+		wantNamespace string
+		wantName      string
+		wantOK        bool
+	}{
+		{"/api/namespaces/orchest/addons", "orchest", "", true},
+		{"/api/namespaces/orchest/addons/nginx-ingress", "orchest", "nginx-ingress", true},
+		{"/api/namespaces/orchest", "", "", false},
+		{"/unrelated/path", "", "", false},
+	}
+
+	for _, c := range cases {
+		namespace, name, ok := parsePath(c.path)
+		if ok != c.wantOK || namespace != c.wantNamespace || name != c.wantName {
+			t.Errorf("parsePath(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				c.path, namespace, name, ok, c.wantNamespace, c.wantName, c.wantOK)
+		}
+	}
+}
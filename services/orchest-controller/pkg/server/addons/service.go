@@ -0,0 +1,114 @@
+package addons
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/orchest/orchest/services/orchest-controller/pkg/addons"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm"
+)
+
+// Service backs both the HTTP and (future) gRPC GetAddonRelease/
+// ListAddonReleases endpoints. It has no transport-specific code so the
+// same business logic can be exposed over either; see addons.proto for the
+// gRPC service definition this would be generated from.
+type Service struct {
+	helmClient helm.HelmClient
+	addons     map[string]addons.Addon
+}
+
+// NewService returns a Service backed by helmClient, serving the given
+// addons keyed by their name as used in ApplicationSpec.Name.
+func NewService(helmClient helm.HelmClient, addons map[string]addons.Addon) *Service {
+	return &Service{helmClient: helmClient, addons: addons}
+}
+
+// helmReleaseName mirrors HelmDeployer.getReleaseName: the deployer names
+// every release "<namespace>-<addon name>".
+func helmReleaseName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
+
+// helmMetadata is the subset of `helm get metadata -o json` this service
+// surfaces.
+type helmMetadata struct {
+	Chart      string    `json:"chart"`
+	Version    string    `json:"version"`
+	AppVersion string    `json:"appVersion"`
+	Revision   int       `json:"revision"`
+	Updated    time.Time `json:"deployedAt"`
+}
+
+// GetAddonRelease returns the current state of the named addon's Helm
+// release in namespace.
+func (s *Service) GetAddonRelease(ctx context.Context, namespace, name string) (*AddonRelease, error) {
+	addon, ok := s.addons[name]
+	if !ok {
+		return nil, fmt.Errorf("addons: no addon named %q", name)
+	}
+
+	releaseName := helmReleaseName(namespace, name)
+
+	rawMetadata, err := s.helmClient.GetReleaseMetadata(ctx, releaseName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("addons: failed to get release metadata for %q: %w", name, err)
+	}
+
+	var metadata helmMetadata
+	if err := json.Unmarshal([]byte(rawMetadata), &metadata); err != nil {
+		return nil, fmt.Errorf("addons: failed to parse release metadata for %q: %w", name, err)
+	}
+
+	rawValues, err := s.helmClient.GetReleaseValues(ctx, releaseName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("addons: failed to get release values for %q: %w", name, err)
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(rawValues), &values); err != nil {
+		return nil, fmt.Errorf("addons: failed to parse release values for %q: %w", name, err)
+	}
+
+	resources, err := addon.Status(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("addons: failed to get resource status for %q: %w", name, err)
+	}
+
+	return &AddonRelease{
+		Name:         name,
+		Namespace:    namespace,
+		Chart:        metadata.Chart,
+		ChartVersion: metadata.Version,
+		Revision:     metadata.Revision,
+		LastDeployed: metadata.Updated,
+		Values:       redactValues(values),
+		Resources:    resources,
+	}, nil
+}
+
+// ListAddonReleases returns the current state of every addon's Helm
+// release in namespace, sorted by addon name. An addon whose release
+// can't be read (most commonly: it isn't installed yet) doesn't fail the
+// whole call -- its entry carries Error instead so callers can still see
+// every other addon's state.
+func (s *Service) ListAddonReleases(ctx context.Context, namespace string) ([]*AddonRelease, error) {
+	names := make([]string, 0, len(s.addons))
+	for name := range s.addons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	releases := make([]*AddonRelease, 0, len(names))
+	for _, name := range names {
+		release, err := s.GetAddonRelease(ctx, namespace, name)
+		if err != nil {
+			release = &AddonRelease{Name: name, Namespace: namespace, Error: err.Error()}
+		}
+		releases = append(releases, release)
+	}
+
+	return releases, nil
+}
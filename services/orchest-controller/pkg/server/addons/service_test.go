@@ -0,0 +1,131 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	orchestaddons "github.com/orchest/orchest/services/orchest-controller/pkg/addons"
+	orchestv1alpha1 "github.com/orchest/orchest/services/orchest-controller/pkg/apis/orchest/v1alpha1"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/helm/mocks"
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
+)
+
+type stubAddon struct {
+	statuses []statuscheck.ResourceStatus
+	err      error
+}
+
+func (s *stubAddon) Enable(ctx context.Context, _ []orchestaddons.PreInstallHookFn, _ string, _ *orchestv1alpha1.ApplicationSpec) error {
+	return nil
+}
+
+func (s *stubAddon) Uninstall(ctx context.Context, namespace string) error { return nil }
+
+func (s *stubAddon) Status(ctx context.Context, namespace string) ([]statuscheck.ResourceStatus, error) {
+	return s.statuses, s.err
+}
+
+func TestHelmReleaseName(t *testing.T) {
+	if got, want := helmReleaseName("orchest", "nginx-ingress"), "orchest-nginx-ingress"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestService_GetAddonRelease_RedactsSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	helmClient := mocks.NewMockHelmClient(ctrl)
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), "orchest-nginx-ingress", "orchest").
+		Return(`{"chart":"nginx-ingress","version":"1.2.3","revision":2,"deployedAt":"2026-01-02T15:04:05Z"}`, nil)
+	helmClient.EXPECT().GetReleaseValues(gomock.Any(), "orchest-nginx-ingress", "orchest").
+		Return(`{"service":{"type":"LoadBalancer"},"auth":{"adminPassword":"hunter2"},"extraEnvSecrets":[{"name":"db","token":"hunter3"}]}`, nil)
+
+	addon := &stubAddon{statuses: []statuscheck.ResourceStatus{{Kind: "Deployment", Name: "nginx-ingress", Ready: true}}}
+
+	service := NewService(helmClient, map[string]orchestaddons.Addon{"nginx-ingress": addon})
+
+	release, err := service.GetAddonRelease(context.Background(), "orchest", "nginx-ingress")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if release.ChartVersion != "1.2.3" || release.Revision != 2 {
+		t.Fatalf("unexpected metadata: %+v", release)
+	}
+	if want := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC); !release.LastDeployed.Equal(want) {
+		t.Fatalf("expected LastDeployed %v, got %v", want, release.LastDeployed)
+	}
+
+	auth, ok := release.Values["auth"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected auth values to be present, got %+v", release.Values)
+	}
+	if auth["adminPassword"] != redactedPlaceholder {
+		t.Fatalf("expected adminPassword to be redacted, got %v", auth["adminPassword"])
+	}
+
+	extraSecrets, ok := release.Values["extraEnvSecrets"].([]interface{})
+	if !ok || len(extraSecrets) != 1 {
+		t.Fatalf("expected extraEnvSecrets to be present, got %+v", release.Values)
+	}
+	secret, ok := extraSecrets[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected extraEnvSecrets[0] to be a map, got %+v", extraSecrets[0])
+	}
+	if secret["token"] != redactedPlaceholder {
+		t.Fatalf("expected a secret nested in a list to be redacted, got %v", secret["token"])
+	}
+	if secret["name"] != "db" {
+		t.Fatalf("expected non-secret list fields to survive redaction, got %v", secret["name"])
+	}
+}
+
+func TestService_ListAddonReleases_OneAddonNotInstalledDoesNotFailTheList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	helmClient := mocks.NewMockHelmClient(ctrl)
+
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), "orchest-argo-workflows", "orchest").
+		Return("", errors.New("release: not found"))
+	helmClient.EXPECT().GetReleaseMetadata(gomock.Any(), "orchest-nginx-ingress", "orchest").
+		Return(`{"chart":"nginx-ingress","version":"1.2.3","revision":2}`, nil)
+	helmClient.EXPECT().GetReleaseValues(gomock.Any(), "orchest-nginx-ingress", "orchest").
+		Return(`{}`, nil)
+
+	addons := map[string]orchestaddons.Addon{
+		"argo-workflows": &stubAddon{},
+		"nginx-ingress":  &stubAddon{statuses: []statuscheck.ResourceStatus{{Kind: "Deployment", Name: "nginx-ingress", Ready: true}}},
+	}
+	service := NewService(helmClient, addons)
+
+	releases, err := service.ListAddonReleases(context.Background(), "orchest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("expected an entry for every addon, got %+v", releases)
+	}
+
+	// Sorted by name: argo-workflows before nginx-ingress.
+	if releases[0].Name != "argo-workflows" || releases[0].Error == "" {
+		t.Fatalf("expected argo-workflows to carry an error, got %+v", releases[0])
+	}
+	if releases[1].Name != "nginx-ingress" || releases[1].Error != "" || releases[1].ChartVersion != "1.2.3" {
+		t.Fatalf("expected nginx-ingress to be fully populated, got %+v", releases[1])
+	}
+}
+
+func TestService_GetAddonRelease_UnknownAddon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	helmClient := mocks.NewMockHelmClient(ctrl)
+
+	service := NewService(helmClient, map[string]orchestaddons.Addon{})
+
+	_, err := service.GetAddonRelease(context.Background(), "orchest", "missing")
+	if err == nil {
+		t.Fatal("expected an error for an unknown addon")
+	}
+}
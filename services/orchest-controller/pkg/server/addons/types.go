@@ -0,0 +1,82 @@
+// Package addons exposes a read-only API over the Helm releases managed by
+// the orchest-controller's addon deployers, so the Orchest UI and
+// orchest-cli can introspect what's actually installed instead of
+// inferring it from the OrchestCluster spec.
+package addons
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/orchest/orchest/services/orchest-controller/pkg/statuscheck"
+)
+
+// AddonRelease describes the current state of a single addon's Helm
+// release.
+type AddonRelease struct {
+	Name         string                       `json:"name"`
+	Namespace    string                       `json:"namespace"`
+	Chart        string                       `json:"chart"`
+	ChartVersion string                       `json:"chartVersion"`
+	Revision     int                          `json:"revision"`
+	LastDeployed time.Time                    `json:"lastDeployed"`
+	Values       map[string]interface{}       `json:"values"`
+	Resources    []statuscheck.ResourceStatus `json:"resources"`
+
+	// Error is set instead of the fields above when this addon's release
+	// state couldn't be read, e.g. because it hasn't been installed yet.
+	// ListAddonReleases surfaces this per-addon rather than failing the
+	// whole request over one addon that isn't deployed.
+	Error string `json:"error,omitempty"`
+}
+
+// redactedKeys matches value keys that are redacted before an AddonRelease
+// is ever returned from the API, regardless of how they ended up in the
+// release's values (a literal, a resolved secret, ...).
+var redactedKeys = regexp.MustCompile(`(?i)(password|secret|token|key|cookie|credential)`)
+
+const redactedPlaceholder = "<redacted>"
+
+// redactValues returns a copy of values in which every scalar value whose
+// key matches redactedKeys is replaced by redactedPlaceholder. Map and
+// slice values are recursed into (e.g. a Secret nested inside an `env:`
+// list) rather than replaced, even when their own key matches.
+func redactValues(values map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(values))
+
+	for key, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			redacted[key] = redactValues(v)
+		case []interface{}:
+			redacted[key] = redactSlice(v)
+		default:
+			if redactedKeys.MatchString(key) {
+				redacted[key] = redactedPlaceholder
+			} else {
+				redacted[key] = value
+			}
+		}
+	}
+
+	return redacted
+}
+
+// redactSlice applies redactValues to every map element of values,
+// recursing into further nested slices, and leaves scalar elements as-is.
+func redactSlice(values []interface{}) []interface{} {
+	redacted := make([]interface{}, len(values))
+
+	for i, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			redacted[i] = redactValues(v)
+		case []interface{}:
+			redacted[i] = redactSlice(v)
+		default:
+			redacted[i] = value
+		}
+	}
+
+	return redacted
+}
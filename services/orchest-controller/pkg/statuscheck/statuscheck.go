@@ -0,0 +1,104 @@
+// Package statuscheck inspects a Helm release's rendered manifest against
+// live cluster state and reports which of its resources are still not
+// ready, modeled on the ready.go/wait.go helpers used by onap's k8splugin.
+package statuscheck
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResourceStatus is the readiness of a single resource found in a rendered
+// manifest.
+type ResourceStatus struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Ready     bool
+	Message   string
+}
+
+// manifestResource is the subset of a rendered manifest document needed to
+// identify the live object to check.
+type manifestResource struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// checkerFn fetches the live state of name/namespace and reports its
+// readiness.
+type checkerFn func(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error)
+
+var checkers = map[string]checkerFn{
+	"Deployment":            checkDeployment,
+	"StatefulSet":           checkStatefulSet,
+	"DaemonSet":             checkDaemonSet,
+	"Job":                   checkJob,
+	"PersistentVolumeClaim": checkPVC,
+	"Service":               checkService,
+}
+
+// Check walks every resource in the rendered manifest and reports its
+// readiness. Kinds without a registered checker (e.g. ConfigMap, Secret)
+// have no meaningful readiness signal of their own and are reported ready.
+func Check(ctx context.Context, client kubernetes.Interface, defaultNamespace, manifest string) ([]ResourceStatus, error) {
+	var statuses []ResourceStatus
+
+	for _, doc := range documentSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		var resource manifestResource
+		if err := yaml.Unmarshal([]byte(doc), &resource); err != nil {
+			return nil, fmt.Errorf("statuscheck: failed to parse manifest document: %w", err)
+		}
+		if resource.Kind == "" || resource.Metadata.Name == "" {
+			continue
+		}
+
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+
+		check, ok := checkers[resource.Kind]
+		if !ok {
+			statuses = append(statuses, ResourceStatus{
+				Kind:      resource.Kind,
+				Name:      resource.Metadata.Name,
+				Namespace: namespace,
+				Ready:     true,
+			})
+			continue
+		}
+
+		status, err := check(ctx, client, namespace, resource.Metadata.Name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// AllReady reports whether every resource in statuses is ready.
+func AllReady(statuses []ResourceStatus) bool {
+	for _, status := range statuses {
+		if !status.Ready {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package statuscheck
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx-ingress
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: nginx-ingress-config
+`
+
+func TestCheck_ReadyDeployment(t *testing.T) {
+	replicas := int32(1)
+	client := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-ingress", Namespace: "orchest", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			AvailableReplicas:  1,
+		},
+	})
+
+	statuses, err := Check(context.Background(), client, "orchest", testManifest)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !AllReady(statuses) {
+		t.Fatalf("expected all resources ready, got %+v", statuses)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(statuses))
+	}
+}
+
+func TestCheck_PendingDeployment(t *testing.T) {
+	replicas := int32(2)
+	client := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-ingress", Namespace: "orchest", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			AvailableReplicas:  1,
+		},
+	})
+
+	statuses, err := Check(context.Background(), client, "orchest", testManifest)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if AllReady(statuses) {
+		t.Fatal("expected the deployment to not be ready yet")
+	}
+}
+
+func TestCheck_NotYetCreatedIsNotReadyNotError(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	statuses, err := Check(context.Background(), client, "orchest", testManifest)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if AllReady(statuses) {
+		t.Fatal("expected the not-yet-created deployment to not be ready")
+	}
+	deployment := statuses[0]
+	if deployment.Message != "not created yet" {
+		t.Fatalf("expected a not-created-yet message, got %+v", deployment)
+	}
+}
+
+func TestCheckPVC_Bound(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "userdir-pvc", Namespace: "orchest"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	})
+
+	status, err := checkPVC(context.Background(), client, "orchest", "userdir-pvc")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !status.Ready {
+		t.Fatalf("expected a bound PVC to be ready, got %+v", status)
+	}
+}
@@ -0,0 +1,57 @@
+package statuscheck
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func checkPVC(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("PersistentVolumeClaim", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "PersistentVolumeClaim", Name: name, Namespace: namespace}
+
+	if pvc.Status.Phase == corev1.ClaimBound {
+		status.Ready = true
+	} else {
+		status.Message = fmt.Sprintf("claim is %s", pvc.Status.Phase)
+	}
+
+	return status, nil
+}
+
+func checkService(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	service, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("Service", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "Service", Name: name, Namespace: namespace}
+
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		// ClusterIP and NodePort Services are ready as soon as they exist.
+		status.Ready = true
+		return status, nil
+	}
+
+	if len(service.Status.LoadBalancer.Ingress) > 0 {
+		status.Ready = true
+	} else {
+		status.Message = "waiting for load balancer ingress to be assigned"
+	}
+
+	return status, nil
+}
@@ -0,0 +1,122 @@
+package statuscheck
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// notCreatedYet is the ResourceStatus reported for a resource that a Helm
+// upgrade/install has rendered but that the API server has not yet created —
+// the normal state in the moments right after a release is applied.
+func notCreatedYet(kind, name, namespace string) ResourceStatus {
+	return ResourceStatus{Kind: kind, Name: name, Namespace: namespace, Message: "not created yet"}
+}
+
+func checkDeployment(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("Deployment", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "Deployment", Name: name, Namespace: namespace}
+
+	wantReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		wantReplicas = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case deployment.Status.ObservedGeneration < deployment.Generation:
+		status.Message = "waiting for controller to observe the latest spec"
+	case deployment.Status.AvailableReplicas < wantReplicas:
+		status.Message = fmt.Sprintf("%d/%d replicas available", deployment.Status.AvailableReplicas, wantReplicas)
+	default:
+		status.Ready = true
+	}
+
+	return status, nil
+}
+
+func checkStatefulSet(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("StatefulSet", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "StatefulSet", Name: name, Namespace: namespace}
+
+	wantReplicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		wantReplicas = *statefulSet.Spec.Replicas
+	}
+
+	switch {
+	case statefulSet.Status.ObservedGeneration < statefulSet.Generation:
+		status.Message = "waiting for controller to observe the latest spec"
+	case statefulSet.Status.ReadyReplicas < wantReplicas:
+		status.Message = fmt.Sprintf("%d/%d replicas ready", statefulSet.Status.ReadyReplicas, wantReplicas)
+	default:
+		status.Ready = true
+	}
+
+	return status, nil
+}
+
+func checkDaemonSet(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("DaemonSet", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "DaemonSet", Name: name, Namespace: namespace}
+
+	switch {
+	case daemonSet.Status.ObservedGeneration < daemonSet.Generation:
+		status.Message = "waiting for controller to observe the latest spec"
+	case daemonSet.Status.NumberUnavailable > 0:
+		status.Message = fmt.Sprintf("%d pods unavailable", daemonSet.Status.NumberUnavailable)
+	default:
+		status.Ready = true
+	}
+
+	return status, nil
+}
+
+func checkJob(ctx context.Context, client kubernetes.Interface, namespace, name string) (ResourceStatus, error) {
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notCreatedYet("Job", name, namespace), nil
+	}
+	if err != nil {
+		return ResourceStatus{}, err
+	}
+
+	status := ResourceStatus{Kind: "Job", Name: name, Namespace: namespace}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == "Complete" && condition.Status == "True" {
+			status.Ready = true
+			return status, nil
+		}
+		if condition.Type == "Failed" && condition.Status == "True" {
+			status.Message = fmt.Sprintf("job failed: %s", condition.Message)
+			return status, nil
+		}
+	}
+
+	status.Message = "job has not completed yet"
+	return status, nil
+}